@@ -1,9 +1,11 @@
 package logs
 
 import (
+	"context"
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
@@ -11,11 +13,31 @@ import (
 	"github.com/stitchfix/flotilla-os/config"
 	"github.com/stitchfix/flotilla-os/exceptions"
 	"github.com/stitchfix/flotilla-os/state"
+	"io"
 	"log"
 	"net/http"
 	"os"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// logsStreamPollInterval is how often LogsStream polls CloudWatch for new
+	// events while a run is still in progress.
+	logsStreamPollInterval = 2 * time.Second
+
+	// logsStreamKeepaliveInterval is how often LogsStream writes an SSE
+	// keepalive comment to defeat idle connection timeouts in proxies.
+	logsStreamKeepaliveInterval = 15 * time.Second
+
+	// logsTextThrottleBackoffInitial/Max bound the backoff LogsText uses
+	// between retries when GetLogEvents is being throttled.
+	logsTextThrottleBackoffInitial = 500 * time.Millisecond
+	logsTextThrottleBackoffMax     = 10 * time.Second
 )
 
 //
@@ -28,8 +50,31 @@ type ECSCloudWatchLogsClient struct {
 	logStreamPrefix    string
 	logsClient         logsClient
 	logger             *log.Logger
+
+	// datetimeFormat and multilinePattern are mutually exclusive; when set,
+	// consecutive events that don't start a new logical event are folded into
+	// the previous one. See logsToMessage.
+	datetimeFormat   string
+	multilinePattern *regexp.Regexp
+
+	// pendingMultiline holds, per log stream, the in-progress logical event
+	// that hasn't yet been terminated by a new match - since pagination can
+	// split a logical event across GetLogEvents calls.
+	multilineMu      sync.Mutex
+	pendingMultiline map[string]string
+
+	retentionManager *RetentionManager
+
+	// autoCreateLogGroup, when set via ecs.log.driver.options.awslogs-create-group,
+	// makes the client transparently create a missing log group (and apply its
+	// retention policy) the first time it's needed, instead of erroring.
+	autoCreateLogGroup bool
 }
 
+// retentionManagerCacheSize bounds the number of distinct log groups whose
+// applied retention policy the RetentionManager remembers.
+const retentionManagerCacheSize = 1024
+
 //
 // Name returns the name of the logs client
 //
@@ -48,6 +93,17 @@ func (cwl *ECSCloudWatchLogsClient) Initialize(conf config.Config) error {
 		awsRegion = conf.GetString("aws_default_region")
 	}
 
+	flotillaMode := conf.GetString("flotilla_mode")
+
+	if len(awsRegion) == 0 && flotillaMode != "test" {
+		discovered, err := discoverEC2Region()
+		if err != nil {
+			return errors.Wrap(err,
+				"ECSCloudWatchLogsClient needs one of [ecs.log.driver.options.awslogs-region] or [aws_default_region] set in config, and EC2 instance metadata region discovery failed")
+		}
+		awsRegion = discovered
+	}
+
 	if len(awsRegion) == 0 {
 		return errors.Errorf(
 			"ECSCloudWatchLogsClient needs one of [ecs.log.driver.options.awslogs-region] or [aws_default_region] set in config")
@@ -76,7 +132,36 @@ func (cwl *ECSCloudWatchLogsClient) Initialize(conf config.Config) error {
 		cwl.logRetentionInDays = int64(30)
 	}
 
-	flotillaMode := conf.GetString("flotilla_mode")
+	datetimeFormat := confLogOptions["awslogs-datetime-format"]
+	multilinePattern := confLogOptions["awslogs-multiline-pattern"]
+	if len(datetimeFormat) > 0 && len(multilinePattern) > 0 {
+		return errors.Errorf(
+			"ECSCloudWatchLogsClient: [ecs.log.driver.options.awslogs-datetime-format] and [ecs.log.driver.options.awslogs-multiline-pattern] are mutually exclusive")
+	}
+	if len(multilinePattern) > 0 {
+		pattern, err := regexp.Compile(multilinePattern)
+		if err != nil {
+			return errors.Wrapf(err, "problem compiling [ecs.log.driver.options.awslogs-multiline-pattern] regex [%s]", multilinePattern)
+		}
+		cwl.multilinePattern = pattern
+	} else if len(datetimeFormat) > 0 {
+		layout, err := strftimeToGoLayout(datetimeFormat)
+		if err != nil {
+			return errors.Wrapf(err, "problem parsing [ecs.log.driver.options.awslogs-datetime-format] [%s]", datetimeFormat)
+		}
+		cwl.datetimeFormat = layout
+	}
+	cwl.pendingMultiline = make(map[string]string)
+
+	if createGroup, ok := confLogOptions["awslogs-create-group"]; ok {
+		parsed, err := strconv.ParseBool(createGroup)
+		if err != nil {
+			return errors.Wrapf(err,
+				"problem parsing [ecs.log.driver.options.awslogs-create-group] as bool from value [%s]", createGroup)
+		}
+		cwl.autoCreateLogGroup = parsed
+	}
+
 	if flotillaMode != "test" {
 		sess := session.Must(session.NewSession(&aws.Config{
 			Region: aws.String(awsRegion)}))
@@ -85,6 +170,13 @@ func (cwl *ECSCloudWatchLogsClient) Initialize(conf config.Config) error {
 	}
 	cwl.logger = log.New(os.Stderr, "[ecscloudwatchlogs] ",
 		log.Ldate|log.Ltime|log.Lshortfile)
+
+	retentionManager, err := NewRetentionManager(cwl.logsClient, retentionManagerCacheSize)
+	if err != nil {
+		return err
+	}
+	cwl.retentionManager = retentionManager
+
 	return cwl.createNamespaceIfNotExists()
 }
 
@@ -92,6 +184,8 @@ func (cwl *ECSCloudWatchLogsClient) Initialize(conf config.Config) error {
 // Logs returns all logs from the log stream identified by handle since lastSeen
 //
 func (cwl *ECSCloudWatchLogsClient) Logs(executable state.Executable, run state.Run, lastSeen *string) (string, *string, error) {
+	cwl.applyExecutableRetention(executable)
+
 	startFromHead := true
 	handle := cwl.toStreamName(executable, run)
 	args := &cloudwatchlogs.GetLogEventsInput{
@@ -104,7 +198,7 @@ func (cwl *ECSCloudWatchLogsClient) Logs(executable state.Executable, run state.
 		args.NextToken = lastSeen
 	}
 
-	result, err := cwl.logsClient.GetLogEvents(args)
+	result, err := cwl.getLogEvents(context.Background(), args)
 	if err != nil {
 		if aerr, ok := err.(awserr.Error); ok {
 			if aerr.Code() == cloudwatchlogs.ErrCodeResourceNotFoundException {
@@ -123,12 +217,290 @@ func (cwl *ECSCloudWatchLogsClient) Logs(executable state.Executable, run state.
 		return "", result.NextForwardToken, nil
 	}
 
-	message := cwl.logsToMessage(result.Events)
+	message := cwl.logsToMessage(handle, result.Events, run.Status == state.StatusStopped)
 	return message, result.NextForwardToken, nil
 }
 
-func (cwl *ECSCloudWatchLogsClient) LogsText(executable state.Executable, run state.Run, w http.ResponseWriter) error {
-	return errors.Errorf("ECSCloudWatchLogsClient does not support LogsText method.")
+//
+// LogsText streams the full log history for run to w as plain text, paginating
+// GetLogEvents until NextForwardToken stops advancing or ctx is cancelled.
+//
+func (cwl *ECSCloudWatchLogsClient) LogsText(ctx context.Context, executable state.Executable, run state.Run, w http.ResponseWriter) error {
+	cwl.applyExecutableRetention(executable)
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return errors.Errorf("ECSCloudWatchLogsClient.LogsText requires a flushable http.ResponseWriter")
+	}
+
+	handle := cwl.toStreamName(executable, run)
+	startFromHead := true
+	var nextToken *string
+	var lastToken string
+	throttleBackoff := logsTextThrottleBackoffInitial
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Transfer-Encoding", "chunked")
+	w.WriteHeader(http.StatusOK)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		args := &cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  &cwl.logNamespace,
+			LogStreamName: &handle,
+			StartFromHead: &startFromHead,
+		}
+		if nextToken != nil {
+			args.NextToken = nextToken
+		}
+
+		result, err := cwl.getLogEvents(ctx, args)
+		if err != nil {
+			if aerr, ok := err.(awserr.Error); ok {
+				if aerr.Code() == cloudwatchlogs.ErrCodeResourceNotFoundException {
+					return exceptions.MissingResource{err.Error()}
+				} else if request.IsErrorThrottle(err) {
+					cwl.logger.Printf(
+						"throttled getting logs text; executable_id: %v, run_id: %s, error: %+v\n",
+						executable.GetExecutableID(), run.RunID, err)
+					select {
+					case <-ctx.Done():
+						return nil
+					case <-time.After(throttleBackoff):
+					}
+					throttleBackoff *= 2
+					if throttleBackoff > logsTextThrottleBackoffMax {
+						throttleBackoff = logsTextThrottleBackoffMax
+					}
+					continue
+				}
+			}
+			return errors.Wrap(err, "problem getting logs text")
+		}
+		throttleBackoff = logsTextThrottleBackoffInitial
+
+		done := result.NextForwardToken == nil || *result.NextForwardToken == lastToken
+		if len(result.Events) > 0 || done {
+			if message := cwl.logsToMessage(handle, result.Events, done); len(message) > 0 {
+				if _, err := io.WriteString(w, message+"\n"); err != nil {
+					return errors.Wrap(err, "problem writing logs text")
+				}
+				flusher.Flush()
+			}
+		}
+
+		if done {
+			return nil
+		}
+		lastToken = *result.NextForwardToken
+		nextToken = result.NextForwardToken
+	}
+}
+
+//
+// LogsStream writes log events for run to w as server-sent events
+//
+func (cwl *ECSCloudWatchLogsClient) LogsStream(ctx context.Context, executable state.Executable, run state.Run, statusFn func() (state.Status, error), w http.ResponseWriter) error {
+	cwl.applyExecutableRetention(executable)
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return errors.Errorf("ECSCloudWatchLogsClient.LogsStream requires a flushable http.ResponseWriter")
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	handle := cwl.toStreamName(executable, run)
+
+	var startTime int64
+	draining := false
+
+	pollTicker := time.NewTicker(logsStreamPollInterval)
+	defer pollTicker.Stop()
+	keepaliveTicker := time.NewTicker(logsStreamKeepaliveInterval)
+	defer keepaliveTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-keepaliveTicker.C:
+			if _, err := io.WriteString(w, ":keepalive\n\n"); err != nil {
+				return errors.Wrap(err, "problem writing logs stream keepalive")
+			}
+			flusher.Flush()
+		case <-pollTicker.C:
+			events, err := cwl.filterLogEvents(handle, startTime)
+			if err != nil {
+				return err
+			}
+
+			for _, event := range events {
+				if err := writeSSELogEvent(w, aws.Int64Value(event.Timestamp), aws.StringValue(event.EventId), aws.StringValue(event.Message)); err != nil {
+					return errors.Wrap(err, "problem writing log event")
+				}
+				if aws.Int64Value(event.Timestamp) >= startTime {
+					startTime = aws.Int64Value(event.Timestamp) + 1
+				}
+			}
+			if len(events) > 0 {
+				flusher.Flush()
+			}
+
+			if draining {
+				return nil
+			}
+
+			status, err := statusFn()
+			if err != nil {
+				return errors.Wrap(err, "problem checking run status for logs stream")
+			}
+			if status == state.StatusStopped {
+				draining = true
+			}
+		}
+	}
+}
+
+//
+// filterLogEvents returns all events on handle since startTime
+//
+func (cwl *ECSCloudWatchLogsClient) filterLogEvents(handle string, startTime int64) ([]*cloudwatchlogs.FilteredLogEvent, error) {
+	input := &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName:   &cwl.logNamespace,
+		LogStreamNames: []*string{&handle},
+		StartTime:      aws.Int64(startTime),
+		Interleaved:    aws.Bool(true),
+	}
+
+	var events []*cloudwatchlogs.FilteredLogEvent
+	for {
+		result, err := cwl.filterLogEventsCall(input)
+		if err != nil {
+			if aerr, ok := err.(awserr.Error); ok {
+				if aerr.Code() == cloudwatchlogs.ErrCodeResourceNotFoundException {
+					return nil, exceptions.MissingResource{err.Error()}
+				} else if request.IsErrorThrottle(err) {
+					cwl.logger.Printf("throttled filtering log events; log_stream: %s, error: %+v\n", handle, err)
+					return cwl.getLogEventsSince(handle, startTime)
+				}
+			}
+			return nil, errors.Wrap(err, "problem filtering log events")
+		}
+
+		events = append(events, result.Events...)
+		if result.NextToken == nil {
+			break
+		}
+		input.NextToken = result.NextToken
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return aws.Int64Value(events[i].Timestamp) < aws.Int64Value(events[j].Timestamp)
+	})
+	return events, nil
+}
+
+//
+// getLogEventsSince is the GetLogEvents fallback for filterLogEvents
+//
+func (cwl *ECSCloudWatchLogsClient) getLogEventsSince(handle string, startTime int64) ([]*cloudwatchlogs.FilteredLogEvent, error) {
+	startFromHead := true
+	args := &cloudwatchlogs.GetLogEventsInput{
+		LogGroupName:  &cwl.logNamespace,
+		LogStreamName: &handle,
+		StartFromHead: &startFromHead,
+	}
+
+	var events []*cloudwatchlogs.FilteredLogEvent
+	for {
+		result, err := cwl.getLogEvents(context.Background(), args)
+		if err != nil {
+			return nil, errors.Wrap(err, "problem getting logs as filter events fallback")
+		}
+
+		for _, event := range result.Events {
+			if aws.Int64Value(event.Timestamp) >= startTime {
+				events = append(events, &cloudwatchlogs.FilteredLogEvent{
+					EventId:   event.EventId,
+					Message:   event.Message,
+					Timestamp: event.Timestamp,
+				})
+			}
+		}
+
+		if result.NextForwardToken == nil || (args.NextToken != nil && *args.NextToken == *result.NextForwardToken) {
+			return events, nil
+		}
+		args.NextToken = result.NextForwardToken
+	}
+}
+
+//
+// SearchLogs runs a FilterLogEvents search with query.FilterPattern against run's log stream
+//
+func (cwl *ECSCloudWatchLogsClient) SearchLogs(executable state.Executable, run state.Run, query LogSearchQuery) (LogSearchResult, error) {
+	handle := cwl.toStreamName(executable, run)
+
+	input := &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName:   &cwl.logNamespace,
+		LogStreamNames: []*string{&handle},
+		FilterPattern:  &query.FilterPattern,
+	}
+	if query.StartTime != nil {
+		input.StartTime = query.StartTime
+	}
+	if query.EndTime != nil {
+		input.EndTime = query.EndTime
+	}
+	if query.NextToken != nil {
+		input.NextToken = query.NextToken
+	}
+
+	result, err := cwl.filterLogEventsCall(input)
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == cloudwatchlogs.ErrCodeResourceNotFoundException {
+			return LogSearchResult{}, exceptions.MissingResource{err.Error()}
+		}
+		return LogSearchResult{}, errors.Wrap(err, "problem searching logs")
+	}
+
+	events := make([]LogSearchEvent, 0, len(result.Events))
+	for _, event := range result.Events {
+		events = append(events, LogSearchEvent{
+			EventID:   aws.StringValue(event.EventId),
+			Timestamp: aws.Int64Value(event.Timestamp),
+			Message:   aws.StringValue(event.Message),
+		})
+	}
+
+	return LogSearchResult{Events: events, NextToken: result.NextToken}, nil
+}
+
+//
+// writeSSELogEvent writes message as an SSE event with id "timestamp-eventID",
+// emitting one "data:" line per line of message since the SSE spec requires
+// every line of a multi-line data field to carry its own "data:" prefix.
+//
+func writeSSELogEvent(w io.Writer, timestamp int64, eventID string, message string) error {
+	if _, err := fmt.Fprintf(w, "id: %d-%s\n", timestamp, eventID); err != nil {
+		return err
+	}
+	for _, line := range strings.Split(message, "\n") {
+		if _, err := fmt.Fprintf(w, "data: %s\n", line); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
 }
 
 func (cwl *ECSCloudWatchLogsClient) toStreamName(executable state.Executable, run state.Run) string {
@@ -137,14 +509,155 @@ func (cwl *ECSCloudWatchLogsClient) toStreamName(executable state.Executable, ru
 		"%s/%s/%s", cwl.logStreamPrefix, executable.GetExecutableResources().ContainerName, arnSplits[len(arnSplits)-1])
 }
 
-func (cwl *ECSCloudWatchLogsClient) logsToMessage(events []*cloudwatchlogs.OutputLogEvent) string {
+//
+// logsToMessage sorts events by timestamp and joins them into a single message,
+// folding multiline events per isNewLogicalEvent. The tail of an in-progress
+// logical event is held in pendingMultiline (keyed by handle) and flushed to
+// the returned message only once a new logical event arrives or final is true,
+// since a page of events from GetLogEvents can end mid-event.
+//
+func (cwl *ECSCloudWatchLogsClient) logsToMessage(handle string, events []*cloudwatchlogs.OutputLogEvent, final bool) string {
 	sort.Sort(byTimestamp(events))
 
-	messages := make([]string, len(events))
-	for i, event := range events {
-		messages[i] = *event.Message
+	cwl.multilineMu.Lock()
+	defer cwl.multilineMu.Unlock()
+
+	pending := cwl.pendingMultiline[handle]
+
+	var lines []string
+	for _, event := range events {
+		message := *event.Message
+		if cwl.isNewLogicalEvent(message) {
+			if len(pending) > 0 {
+				lines = append(lines, pending)
+			}
+			pending = message
+		} else if len(pending) > 0 {
+			pending = pending + "\n" + message
+		} else {
+			pending = message
+		}
+	}
+
+	if final {
+		if len(pending) > 0 {
+			lines = append(lines, pending)
+		}
+		delete(cwl.pendingMultiline, handle)
+	} else {
+		cwl.pendingMultiline[handle] = pending
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// strftimeDirectives maps the strftime directives Docker's own
+// awslogs-datetime-format docs use to their Go reference-time equivalents
+var strftimeDirectives = map[byte]string{
+	'Y': "2006", 'y': "06",
+	'm': "01",
+	'd': "02",
+	'H': "15", 'I': "03",
+	'M': "04",
+	'S': "05",
+	'p': "PM",
+	'b': "Jan", 'B': "January",
+	'a': "Mon", 'A': "Monday",
+	'z': "-0700", 'Z': "MST",
+	'%': "%",
+}
+
+//
+// strftimeToGoLayout translates a strftime-style format (e.g. "%Y-%m-%d
+// %H:%M:%S") into the equivalent Go reference-time layout. format is assumed
+// to already be a Go layout, and is returned unchanged, if it contains no "%"
+// directives.
+//
+func strftimeToGoLayout(format string) (string, error) {
+	if !strings.ContainsRune(format, '%') {
+		return format, nil
+	}
+
+	var layout strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			layout.WriteByte(format[i])
+			continue
+		}
+		i++
+		if i >= len(format) {
+			return "", errors.Errorf("trailing %% in strftime format [%s]", format)
+		}
+		replacement, ok := strftimeDirectives[format[i]]
+		if !ok {
+			return "", errors.Errorf("unsupported strftime directive [%%%c] in format [%s]", format[i], format)
+		}
+		layout.WriteString(replacement)
+	}
+	return layout.String(), nil
+}
+
+//
+// isNewLogicalEvent reports whether message starts a new logical log event
+//
+func (cwl *ECSCloudWatchLogsClient) isNewLogicalEvent(message string) bool {
+	if cwl.multilinePattern != nil {
+		return cwl.multilinePattern.MatchString(message)
+	}
+	if len(cwl.datetimeFormat) > 0 {
+		firstLine := message
+		if idx := strings.Index(message, "\n"); idx >= 0 {
+			firstLine = message[:idx]
+		}
+		_, err := time.Parse(cwl.datetimeFormat, firstLine)
+		return err == nil
+	}
+	return true
+}
+
+//
+// ApplyLogRetention records retentionDays as the desired retention for
+// executable and applies the resulting effective retention (the longest
+// requested by any executable sharing its log group, see
+// RetentionManager.EffectiveRetention) to that group immediately, so the
+// change takes effect without waiting for the executable's next run.
+//
+func (cwl *ECSCloudWatchLogsClient) ApplyLogRetention(executable state.Executable, retentionDays int64) error {
+	logGroupName := cwl.logGroupForExecutable(executable)
+	cwl.retentionManager.SetDesired(logGroupName, executable.GetExecutableID(), retentionDays)
+
+	effective, _ := cwl.retentionManager.EffectiveRetention(logGroupName)
+	return cwl.retentionManager.Apply(logGroupName, effective)
+}
+
+//
+// applyExecutableRetention applies the effective retention for executable's
+// log group (see RetentionManager.EffectiveRetention), if any executable
+// sharing it has requested one via ApplyLogRetention, to that group. The
+// RetentionManager skips the PutRetentionPolicy call once it's been applied
+// for a given log group, so this is cheap to call on every run.
+//
+func (cwl *ECSCloudWatchLogsClient) applyExecutableRetention(executable state.Executable) {
+	logGroupName := cwl.logGroupForExecutable(executable)
+	if _, ok := cwl.retentionManager.Desired(logGroupName, executable.GetExecutableID()); !ok {
+		return
 	}
-	return strings.Join(messages, "\n")
+
+	effective, ok := cwl.retentionManager.EffectiveRetention(logGroupName)
+	if !ok {
+		return
+	}
+	if err := cwl.retentionManager.Apply(logGroupName, effective); err != nil {
+		cwl.logger.Printf("problem applying log retention policy; executable_id: %v, error: %+v\n",
+			executable.GetExecutableID(), err)
+	}
+}
+
+//
+// logGroupForExecutable returns the log group executable's logs are written to
+//
+func (cwl *ECSCloudWatchLogsClient) logGroupForExecutable(executable state.Executable) string {
+	return cwl.logNamespace
 }
 
 func (cwl *ECSCloudWatchLogsClient) createNamespaceIfNotExists() error {
@@ -194,3 +707,89 @@ func (cwl *ECSCloudWatchLogsClient) createNamespace() error {
 	}
 	return nil
 }
+
+//
+// getLogEvents wraps GetLogEvents with the awslogs-create-group retry
+//
+func (cwl *ECSCloudWatchLogsClient) getLogEvents(ctx context.Context, args *cloudwatchlogs.GetLogEventsInput) (*cloudwatchlogs.GetLogEventsOutput, error) {
+	result, err := cwl.logsClient.GetLogEventsWithContext(ctx, args)
+	if err == nil || !cwl.autoCreateLogGroup {
+		return result, err
+	}
+
+	aerr, ok := err.(awserr.Error)
+	if !ok || aerr.Code() != cloudwatchlogs.ErrCodeResourceNotFoundException {
+		return result, err
+	}
+
+	if createErr := cwl.ensureLogGroupExists(*args.LogGroupName); createErr != nil {
+		cwl.logger.Printf("problem auto-creating log group [%s]: %+v\n", *args.LogGroupName, createErr)
+		return result, err
+	}
+
+	return cwl.logsClient.GetLogEventsWithContext(ctx, args)
+}
+
+//
+// filterLogEventsCall wraps FilterLogEvents with the same awslogs-create-group
+// retry as getLogEvents, so filterLogEvents and SearchLogs get the same
+// auto-create behavior as the GetLogEvents path.
+//
+func (cwl *ECSCloudWatchLogsClient) filterLogEventsCall(args *cloudwatchlogs.FilterLogEventsInput) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+	result, err := cwl.logsClient.FilterLogEvents(args)
+	if err == nil || !cwl.autoCreateLogGroup {
+		return result, err
+	}
+
+	aerr, ok := err.(awserr.Error)
+	if !ok || aerr.Code() != cloudwatchlogs.ErrCodeResourceNotFoundException {
+		return result, err
+	}
+
+	if createErr := cwl.ensureLogGroupExists(*args.LogGroupName); createErr != nil {
+		cwl.logger.Printf("problem auto-creating log group [%s]: %+v\n", *args.LogGroupName, createErr)
+		return result, err
+	}
+
+	return cwl.logsClient.FilterLogEvents(args)
+}
+
+//
+// ensureLogGroupExists creates logGroupName and applies the client's default retention policy
+//
+func (cwl *ECSCloudWatchLogsClient) ensureLogGroupExists(logGroupName string) error {
+	_, err := cwl.logsClient.CreateLogGroup(&cloudwatchlogs.CreateLogGroupInput{
+		LogGroupName: &logGroupName,
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == cloudwatchlogs.ErrCodeResourceAlreadyExistsException {
+			return nil
+		}
+		return errors.Wrapf(err, "problem creating log group [%s]", logGroupName)
+	}
+
+	_, err = cwl.logsClient.PutRetentionPolicy(&cloudwatchlogs.PutRetentionPolicyInput{
+		LogGroupName:    &logGroupName,
+		RetentionInDays: &cwl.logRetentionInDays,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "problem setting retention policy for newly created log group [%s]", logGroupName)
+	}
+	return nil
+}
+
+//
+// discoverEC2Region queries the EC2 Instance Metadata Service for this instance's region
+//
+func discoverEC2Region() (string, error) {
+	sess, err := session.NewSession()
+	if err != nil {
+		return "", errors.Wrap(err, "problem creating session for EC2 instance metadata region discovery")
+	}
+
+	region, err := ec2metadata.New(sess).Region()
+	if err != nil {
+		return "", errors.Wrap(err, "problem querying EC2 instance metadata for region")
+	}
+	return region, nil
+}
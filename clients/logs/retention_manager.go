@@ -0,0 +1,151 @@
+package logs
+
+import (
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+)
+
+//
+// allowedLogRetentionDays enumerates the retention-in-days values CloudWatch
+// Logs accepts for PutRetentionPolicy.
+//
+var allowedLogRetentionDays = map[int64]bool{
+	1: true, 3: true, 5: true, 7: true, 14: true, 30: true, 60: true, 90: true,
+	120: true, 150: true, 180: true, 365: true, 400: true, 545: true, 731: true,
+	1827: true, 3653: true,
+}
+
+//
+// ValidateRetentionDays returns an error if days is not one of the values
+// CloudWatch Logs accepts for PutRetentionPolicy.
+//
+func ValidateRetentionDays(days int64) error {
+	if !allowedLogRetentionDays[days] {
+		return errors.Errorf("invalid log retention days [%d]; must be one of CloudWatch's allowed retention values", days)
+	}
+	return nil
+}
+
+//
+// RetentionManager applies CloudWatch log retention policies per log group
+// and caches the last-applied value in an LRU, so that repeated runs of the
+// same executable don't each re-issue PutRetentionPolicy against a group that
+// already has the right policy applied.
+//
+type RetentionManager struct {
+	logsClient logsClient
+	cache      *lru.Cache
+	mu         sync.Mutex
+
+	// desired holds, per log group, the last retention-in-days requested via
+	// SetDesired for each executable ID writing to that group. Flotilla
+	// currently shares one log group across every executable (see
+	// ECSCloudWatchLogsClient.logGroupForExecutable), so a group's policy can't
+	// simply be overwritten by whichever executable called SetDesired last -
+	// EffectiveRetention takes the max across all executables sharing a group,
+	// so a compliance-sensitive executable's retention can't be silently
+	// shortened by another executable's request.
+	//
+	// This map is process-local: it's only populated by calls to SetDesired
+	// (from ApplyLogRetention), so it doesn't survive a restart and doesn't
+	// reflect a run's executable until an operator has requested retention for
+	// it at least once in the current process. Persisting desired retention
+	// against the executable itself, so it's applied automatically on an
+	// executable's first observed run, requires a field on state.Executable -
+	// that package isn't part of this checkout, so it isn't implemented here.
+	desired map[string]map[string]int64
+}
+
+//
+// NewRetentionManager constructs a RetentionManager whose cache holds up to
+// cacheSize log groups' applied retention values.
+//
+func NewRetentionManager(lc logsClient, cacheSize int) (*RetentionManager, error) {
+	cache, err := lru.New(cacheSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem constructing log retention manager cache")
+	}
+	return &RetentionManager{logsClient: lc, cache: cache, desired: make(map[string]map[string]int64)}, nil
+}
+
+//
+// SetDesired records retentionDays as the desired retention for executableID
+// within logGroupName
+//
+func (rm *RetentionManager) SetDesired(logGroupName string, executableID string, retentionDays int64) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	if rm.desired[logGroupName] == nil {
+		rm.desired[logGroupName] = make(map[string]int64)
+	}
+	rm.desired[logGroupName][executableID] = retentionDays
+}
+
+//
+// Desired returns the retention days most recently set for executableID
+// within logGroupName via SetDesired, and false if none has been set
+//
+func (rm *RetentionManager) Desired(logGroupName string, executableID string) (int64, bool) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	days, ok := rm.desired[logGroupName][executableID]
+	return days, ok
+}
+
+//
+// EffectiveRetention returns the longest retention any executable sharing
+// logGroupName has requested via SetDesired, and false if none has been set.
+// Since multiple executables can share a log group, applying any single
+// executable's request directly to the group could shorten another
+// executable's retention out from under it; the max is the only value safe
+// to apply for all of them at once.
+//
+func (rm *RetentionManager) EffectiveRetention(logGroupName string) (int64, bool) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	var max int64
+	found := false
+	for _, days := range rm.desired[logGroupName] {
+		if !found || days > max {
+			max = days
+			found = true
+		}
+	}
+	return max, found
+}
+
+//
+// Apply ensures logGroupName has retentionDays applied, skipping the
+// PutRetentionPolicy call when the cache shows it was already applied.
+//
+func (rm *RetentionManager) Apply(logGroupName string, retentionDays int64) error {
+	if err := ValidateRetentionDays(retentionDays); err != nil {
+		return err
+	}
+
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if cached, ok := rm.cache.Get(logGroupName); ok && cached.(int64) == retentionDays {
+		return nil
+	}
+
+	_, err := rm.logsClient.PutRetentionPolicy(&cloudwatchlogs.PutRetentionPolicyInput{
+		LogGroupName:    &logGroupName,
+		RetentionInDays: &retentionDays,
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == cloudwatchlogs.ErrCodeResourceNotFoundException {
+			return errors.Wrapf(err, "log group [%s] does not exist", logGroupName)
+		}
+		return errors.Wrapf(err, "problem applying retention policy [%d days] to log group [%s]", retentionDays, logGroupName)
+	}
+
+	rm.cache.Add(logGroupName, retentionDays)
+	return nil
+}
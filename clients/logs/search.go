@@ -0,0 +1,34 @@
+package logs
+
+//
+// LogSearchQuery describes a FilterLogEvents-backed search against a single
+// run's logs: FilterPattern is a CloudWatch Logs Insights filter pattern (or
+// a plain substring), StartTime/EndTime optionally bound the search window in
+// epoch millis, and NextToken pages through results already seen.
+//
+type LogSearchQuery struct {
+	FilterPattern string
+	StartTime     *int64
+	EndTime       *int64
+	NextToken     *string
+}
+
+//
+// LogSearchEvent is a single matching log line from a LogSearchQuery, with
+// enough identity (EventID, Timestamp) for a caller to deep-link to it in a
+// run's log viewer.
+//
+type LogSearchEvent struct {
+	EventID   string
+	Timestamp int64
+	Message   string
+}
+
+//
+// LogSearchResult is one page of LogSearchEvent matches. NextToken is nil
+// once there are no further pages.
+//
+type LogSearchResult struct {
+	Events    []LogSearchEvent
+	NextToken *string
+}
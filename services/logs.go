@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"github.com/stitchfix/flotilla-os/clients/logs"
 	"github.com/stitchfix/flotilla-os/config"
 	"github.com/stitchfix/flotilla-os/state"
@@ -9,7 +10,10 @@ import (
 
 type LogService interface {
 	Logs(runID string, lastSeen *string) (string, *string, error)
-	LogsText(runID string, w http.ResponseWriter) error
+	LogsText(ctx context.Context, runID string, w http.ResponseWriter) error
+	LogsStream(ctx context.Context, runID string, w http.ResponseWriter) error
+	UpdateLogRetention(executableType state.ExecutableType, executableID string, retentionDays int64) error
+	SearchLogs(runID string, query logs.LogSearchQuery) (logs.LogSearchResult, error)
 }
 
 type logService struct {
@@ -49,7 +53,7 @@ func (ls *logService) Logs(runID string, lastSeen *string) (string, *string, err
 	return ls.lc.Logs(executable, run, lastSeen)
 }
 
-func (ls *logService) LogsText(runID string, w http.ResponseWriter) error {
+func (ls *logService) LogsText(ctx context.Context, runID string, w http.ResponseWriter) error {
 	run, err := ls.sm.GetRun(runID)
 	if err != nil {
 		return err
@@ -69,5 +73,95 @@ func (ls *logService) LogsText(runID string, w http.ResponseWriter) error {
 	}
 	executable, err := ls.sm.GetExecutableByTypeAndID(*run.ExecutableType, *run.ExecutableID)
 
-	return ls.lc.LogsText(executable, run, w)
+	return ls.lc.LogsText(ctx, executable, run, w)
+}
+
+//
+// LogsStream streams logs for a run to w as they're produced via SSE
+//
+func (ls *logService) LogsStream(ctx context.Context, runID string, w http.ResponseWriter) error {
+	run, err := ls.sm.GetRun(runID)
+	if err != nil {
+		return err
+	}
+
+	if run.Status != state.StatusRunning && run.Status != state.StatusStopped {
+		// Won't have logs yet
+		return nil
+	}
+
+	if run.ExecutableType == nil {
+		defaultExecutableType := state.ExecutableTypeDefinition
+		run.ExecutableType = &defaultExecutableType
+	}
+	if run.ExecutableID == nil {
+		run.ExecutableID = &run.DefinitionID
+	}
+	executable, err := ls.sm.GetExecutableByTypeAndID(*run.ExecutableType, *run.ExecutableID)
+
+	if err != nil && *run.Engine == state.ECSEngine {
+		return err
+	}
+
+	statusFn := func() (state.Status, error) {
+		latest, err := ls.sm.GetRun(runID)
+		if err != nil {
+			return "", err
+		}
+		return latest.Status, nil
+	}
+
+	return ls.lc.LogsStream(ctx, executable, run, statusFn, w)
+}
+
+//
+// UpdateLogRetention validates retentionDays, records it as the given
+// executable's desired retention, and immediately applies the resulting
+// effective retention of the executable's log group (the longest requested by
+// any executable sharing it, see logs.RetentionManager.EffectiveRetention),
+// rather than waiting for its next run.
+// Backs `PUT /v1/logs/retention/{executable_type}/{executable_id}`.
+//
+func (ls *logService) UpdateLogRetention(executableType state.ExecutableType, executableID string, retentionDays int64) error {
+	if err := logs.ValidateRetentionDays(retentionDays); err != nil {
+		return err
+	}
+
+	executable, err := ls.sm.GetExecutableByTypeAndID(executableType, executableID)
+	if err != nil {
+		return err
+	}
+
+	return ls.lc.ApplyLogRetention(executable, retentionDays)
+}
+
+//
+// SearchLogs server-side greps a run's logs for query, rather than forcing
+// the caller to scan the full pagination cursor from Logs() client-side.
+//
+func (ls *logService) SearchLogs(runID string, query logs.LogSearchQuery) (logs.LogSearchResult, error) {
+	run, err := ls.sm.GetRun(runID)
+	if err != nil {
+		return logs.LogSearchResult{}, err
+	}
+
+	if run.Status != state.StatusRunning && run.Status != state.StatusStopped {
+		// Won't have logs yet
+		return logs.LogSearchResult{}, nil
+	}
+
+	if run.ExecutableType == nil {
+		defaultExecutableType := state.ExecutableTypeDefinition
+		run.ExecutableType = &defaultExecutableType
+	}
+	if run.ExecutableID == nil {
+		run.ExecutableID = &run.DefinitionID
+	}
+	executable, err := ls.sm.GetExecutableByTypeAndID(*run.ExecutableType, *run.ExecutableID)
+
+	if err != nil && *run.Engine == state.ECSEngine {
+		return logs.LogSearchResult{}, err
+	}
+
+	return ls.lc.SearchLogs(executable, run, query)
 }
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/stitchfix/flotilla-os/state"
+)
+
+//
+// updateLogRetentionRequest is the JSON body expected by
+// NewUpdateLogRetentionHandler: {"retention_days": 30}
+//
+type updateLogRetentionRequest struct {
+	RetentionDays int64 `json:"retention_days"`
+}
+
+//
+// NewUpdateLogRetentionHandler returns the handler backing
+// `PUT /v1/logs/retention/{executable_type}/{executable_id}`. This checkout
+// contains no router/server setup file to mount it in (only clients/logs and
+// services are present), so it is not reachable as shipped; whoever owns
+// that file needs to register this handler (or its path-parsing logic,
+// parseLogRetentionPath, adapted to their router) at that route before this
+// endpoint is usable.
+//
+func NewUpdateLogRetentionHandler(ls LogService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		executableType, executableID, ok := parseLogRetentionPath(r.URL.Path)
+		if !ok {
+			http.Error(w, "expected path /v1/logs/retention/{executable_type}/{executable_id}", http.StatusBadRequest)
+			return
+		}
+
+		var body updateLogRetentionRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		if err := ls.UpdateLogRetention(state.ExecutableType(executableType), executableID, body.RetentionDays); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+//
+// parseLogRetentionPath extracts executable_type and executable_id from a
+// path of the form /v1/logs/retention/{executable_type}/{executable_id}
+//
+func parseLogRetentionPath(path string) (executableType string, executableID string, ok bool) {
+	const prefix = "/v1/logs/retention/"
+	if !strings.HasPrefix(path, prefix) {
+		return "", "", false
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(path, prefix), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+
+	return parts[0], parts[1], true
+}